@@ -0,0 +1,175 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/antonmedv/expr"
+	"github.com/k1LoW/duration"
+	"github.com/k1LoW/octocov/gh"
+)
+
+var (
+	// percentCondRe also matches a bare number without "%" for backwards
+	// compatibility with the pre-expr `acceptable: "60"` syntax.
+	percentCondRe = regexp.MustCompile(`^\d+(\.\d+)?%?$`)
+	ratioCondRe   = regexp.MustCompile(`^1:\d+(\.\d+)?$`)
+	// durationTokenRe matches a bare duration literal (e.g. "15m", "1.5h",
+	// or the compound "1h30m") embedded in a larger composite condition
+	// such as `current < 1h30m || is_pull_request`, which expr can't parse
+	// on its own. The trailing repeated group lets a run of adjacent
+	// value+unit pairs match as a single token instead of tripping over
+	// word boundaries between them (there's no boundary between the "h"
+	// in "1h" and the "3" in "30m").
+	durationTokenRe = regexp.MustCompile(`\b\d+(?:\.\d+)?(?:h|m|s|ms)(?:\d+(?:\.\d+)?(?:h|m|s|ms))*\b`)
+)
+
+// acceptable evaluates cond as an expr expression exposing `current`, `prev`
+// and `diff` for the metric being checked, plus the same GitHub/env context
+// `CheckIf` exposes. It lets a single rule encode both "no regression"
+// (e.g. `current >= prev - 1`) and "grace on PRs" (e.g. `current < 15m ||
+// is_pull_request`) policies. normalize expands the metric's legacy scalar
+// syntax (`80%`, `1:1.2`, `10m`) into an equivalent `current` comparison so
+// existing configs keep working unchanged.
+func (c *Config) acceptable(cond string, current, prev float64, normalize func(string) (string, error), ghCtx map[string]interface{}) (bool, error) {
+	if cond == "" {
+		return true, nil
+	}
+	e, err := normalize(cond)
+	if err != nil {
+		return false, err
+	}
+	vars := acceptableVariables(current, prev, ghCtx)
+	out, err := expr.Eval(fmt.Sprintf("(%s) == true", e), vars)
+	if err != nil {
+		return false, err
+	}
+	ok, ok2 := out.(bool)
+	if !ok2 {
+		return false, fmt.Errorf("invalid acceptable condition: %s", cond)
+	}
+	return ok, nil
+}
+
+// acceptableGitHubContext builds the GitHub/env/time context shared by every
+// acceptable condition evaluated for a single Config.Acceptable call.
+// Callers evaluating many conditions at once (e.g.
+// coveragePatternsAcceptable, one per matched file) must build this once and
+// reuse it via acceptableVariables rather than recomputing it per
+// evaluation, since it resolves the default branch and current pull request
+// over the GitHub API.
+//
+// It is best-effort: unlike CheckIf, acceptable conditions are routinely
+// evaluated outside of a configured GitHub repository (e.g. local runs), so
+// a missing or unreachable GitHub client degrades
+// `github`/`is_default_branch`/`is_pull_request` instead of returning an
+// error.
+func (c *Config) acceptableGitHubContext() map[string]interface{} {
+	now := time.Now()
+	vars := map[string]interface{}{
+		"year":              now.UTC().Year(),
+		"month":             now.UTC().Month(),
+		"day":               now.UTC().Day(),
+		"hour":              now.UTC().Hour(),
+		"weekday":           int(now.UTC().Weekday()),
+		"env":               envMap(),
+		"is_default_branch": false,
+		"is_pull_request":   false,
+	}
+	if e, err := gh.DecodeGitHubEvent(); err == nil {
+		vars["github"] = map[string]interface{}{
+			"event_name": e.Name,
+			"event":      e.Payload,
+		}
+	}
+	if c.Repository == "" {
+		return vars
+	}
+	repo, err := gh.Parse(c.Repository)
+	if err != nil {
+		return vars
+	}
+	if c.gh == nil {
+		g, err := gh.New()
+		if err != nil {
+			return vars
+		}
+		c.gh = g
+	}
+	ctx := context.Background()
+	if defaultBranch, err := c.gh.GetDefaultBranch(ctx, repo.Owner, repo.Repo); err == nil {
+		if b, err := c.gh.DetectCurrentBranch(ctx); err == nil && b == defaultBranch {
+			vars["is_default_branch"] = true
+		}
+	}
+	if _, err := c.gh.DetectCurrentPullRequestNumber(ctx, repo.Owner, repo.Repo); err == nil {
+		vars["is_pull_request"] = true
+	}
+	return vars
+}
+
+// acceptableVariables merges the per-evaluation current/prev/diff variables
+// into the shared ghCtx built by acceptableGitHubContext.
+func acceptableVariables(current, prev float64, ghCtx map[string]interface{}) map[string]interface{} {
+	vars := make(map[string]interface{}, len(ghCtx)+3)
+	for k, v := range ghCtx {
+		vars[k] = v
+	}
+	vars["current"] = current
+	vars["prev"] = prev
+	vars["diff"] = current - prev
+	return vars
+}
+
+// normalizeCoverageCond expands the legacy scalar percent syntax into a
+// `current` comparison. Both "60" and "60%" are accepted for backwards
+// compatibility.
+func normalizeCoverageCond(cond string) (string, error) {
+	if !percentCondRe.MatchString(cond) {
+		return cond, nil
+	}
+	a, err := strconv.ParseFloat(strings.TrimSuffix(cond, "%"), 64)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("current >= %f", a), nil
+}
+
+func normalizeRatioCond(cond string) (string, error) {
+	if !ratioCondRe.MatchString(cond) {
+		return cond, nil
+	}
+	a, err := strconv.ParseFloat(strings.TrimPrefix(cond, "1:"), 64)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("current >= %f", a), nil
+}
+
+// normalizeExecutionTimeCond expands the legacy scalar duration syntax
+// (`10m`) into a `current` comparison, and also rewrites any bare duration
+// literal embedded in a composite condition (`current < 15m ||
+// is_pull_request`) into its nanosecond value, since expr has no syntax for
+// duration literals of its own.
+func normalizeExecutionTimeCond(cond string) (string, error) {
+	if a, err := duration.Parse(cond); err == nil {
+		return fmt.Sprintf("current <= %f", float64(a)), nil
+	}
+	var tokenErr error
+	out := durationTokenRe.ReplaceAllStringFunc(cond, func(tok string) string {
+		a, err := duration.Parse(tok)
+		if err != nil {
+			tokenErr = err
+			return tok
+		}
+		return fmt.Sprintf("%f", float64(a))
+	})
+	if tokenErr != nil {
+		return "", tokenErr
+	}
+	return out, nil
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/k1LoW/octocov/report"
+)
+
+// coveragePatternsAcceptable checks every ConfigCoverage.AcceptablePatterns
+// rule against the per-file coverage in r, in order, and fails on the
+// first rule that any matched file does not meet, naming every offending
+// file of that rule in the error, so teams can enforce stricter coverage on
+// critical subtrees without splitting the project.
+func (c *Config) coveragePatternsAcceptable(r, rPrev *report.Report, ghCtx map[string]interface{}, opts *report.Options) error {
+	if len(c.Coverage.AcceptablePatterns) == 0 || r.Coverage == nil {
+		return nil
+	}
+	prevFiles := map[string]float64{}
+	if rPrev != nil && rPrev.Coverage != nil {
+		for _, f := range rPrev.Coverage.Files {
+			prevFiles[f.File] = f.Percent()
+		}
+	}
+	for _, pattern := range c.Coverage.AcceptablePatterns {
+		var failed []string
+		for _, f := range r.Coverage.Files {
+			matched, err := doublestar.Match(pattern.Path, f.File)
+			if err != nil {
+				return fmt.Errorf("coverage.acceptablePatterns: %w", err)
+			}
+			if !matched {
+				continue
+			}
+			current := f.Percent()
+			ok, err := c.acceptable(pattern.Acceptable, current, prevFiles[f.File], normalizeCoverageCond, ghCtx)
+			if err != nil {
+				return fmt.Errorf("coverage.acceptablePatterns (%s): %w", pattern.Path, err)
+			}
+			if !ok {
+				failed = append(failed, fmt.Sprintf("%s (%s)", f.File, opts.FormatPercent(current)))
+			}
+		}
+		if len(failed) > 0 {
+			return fmt.Errorf("coverage of files matching %q does not meet the accepted condition `%s`: %s", pattern.Path, pattern.Acceptable, strings.Join(failed, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package config
+
+import "testing"
+
+func TestNormalizeCoverageCond(t *testing.T) {
+	tests := []struct {
+		cond    string
+		want    string
+		wantErr bool
+	}{
+		{"80%", "current >= 80.000000", false},
+		{"80", "current >= 80.000000", false},
+		{"current >= prev - 1", "current >= prev - 1", false},
+	}
+	for _, tt := range tests {
+		got, err := normalizeCoverageCond(tt.cond)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("normalizeCoverageCond(%q) error = %v, wantErr %v", tt.cond, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("normalizeCoverageCond(%q) = %q, want %q", tt.cond, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeRatioCond(t *testing.T) {
+	got, err := normalizeRatioCond("1:1.2")
+	if err != nil {
+		t.Fatalf("normalizeRatioCond() error = %v", err)
+	}
+	if want := "current >= 1.200000"; got != want {
+		t.Errorf("normalizeRatioCond() = %q, want %q", got, want)
+	}
+}
+
+func TestNormalizeExecutionTimeCond(t *testing.T) {
+	tests := []struct {
+		name    string
+		cond    string
+		want    string
+		wantErr bool
+	}{
+		{"bare legacy duration", "10m", "current <= 600000000000.000000", false},
+		{"single-unit literal in composite condition", "current < 15m || is_pull_request", "current < 900000000000.000000 || is_pull_request", false},
+		{"compound duration literal in composite condition", "current < 1h30m || is_pull_request", "current < 5400000000000.000000 || is_pull_request", false},
+		{"passthrough when nothing looks like a duration", "is_pull_request", "is_pull_request", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeExecutionTimeCond(tt.cond)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalizeExecutionTimeCond(%q) error = %v, wantErr %v", tt.cond, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeExecutionTimeCond(%q) = %q, want %q", tt.cond, got, tt.want)
+			}
+		})
+	}
+}
@@ -6,23 +6,25 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/k1LoW/octocov/datastore"
 )
 
 func (c *Config) CentralConfigReady() bool {
-	return (c.Central != nil && c.Central.Enable)
+	return c.Central != nil && c.Central.Enable != nil && *c.Central.Enable
 }
 
 func (c *Config) CentralPushConfigReady() bool {
-	if !c.CentralConfigReady() || !c.Central.Push.Enable || c.GitRoot == "" {
+	if !c.CentralConfigReady() || c.Central.Push == nil || c.Central.Push.Enable == nil || !*c.Central.Push.Enable || c.GitRoot == "" {
 		return false
 	}
-	ok, err := CheckIf(c.Central.Push.If)
+	ok, err := c.CheckIf(c.Central.Push.If)
 	if err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "Skip pushing badges: %v\n", err)
 		return false
 	}
 	if !ok {
-		_, _ = fmt.Fprintf(os.Stderr, "Skip pushing badges: the condition in the `if` section is not met (%s)\n", c.Push.If)
+		_, _ = fmt.Fprintf(os.Stderr, "Skip pushing badges: the condition in the `if` section is not met (%s)\n", c.Central.Push.If)
 		return false
 	}
 	return true
@@ -41,15 +43,69 @@ func (c *Config) BuildCentralConfig() error {
 	if !strings.HasPrefix(c.Central.Root, "/") {
 		c.Central.Root = filepath.Clean(filepath.Join(c.Root(), c.Central.Root))
 	}
-	if c.Central.Reports == "" {
-		c.Central.Reports = defaultReportsDir
+	if len(c.Central.Reports.Datastores) == 0 {
+		c.Central.Reports.Datastores = []string{defaultReportsDatastore}
+	}
+	if len(c.Central.Badges.Datastores) == 0 {
+		c.Central.Badges.Datastores = []string{defaultBadgesDatastore}
+	}
+	for _, u := range c.Central.Reports.Datastores {
+		if err := datastore.ValidateURL(u); err != nil {
+			return fmt.Errorf("central.reports.datastores: %w", err)
+		}
 	}
-	if c.Central.Badges == "" {
-		c.Central.Badges = defaultBadgesDir
+	for _, u := range c.Central.Badges.Datastores {
+		if err := datastore.ValidateURL(u); err != nil {
+			return fmt.Errorf("central.badges.datastores: %w", err)
+		}
 	}
-	if !strings.HasPrefix(c.Central.Badges, "/") {
-		c.Central.Badges = filepath.Clean(filepath.Join(c.Root(), c.Central.Badges))
+	if c.Central.Sign != nil {
+		if _, err := c.signer(); err != nil {
+			return fmt.Errorf("central.sign: %w", err)
+		}
 	}
 
 	return nil
 }
+
+// ReportsDatastoreGroup builds the fan-out Datastore group for central
+// reports from c.Central.Reports.Datastores, caching it so repeated calls
+// reuse the same backend clients. BuildCentralConfig must have been called
+// first so defaults and the root are resolved.
+func (c *Config) ReportsDatastoreGroup() (*datastore.Group, error) {
+	if c.reportsGroup == nil {
+		g, err := c.buildDatastoreGroup(c.Central.Reports.Datastores, c.Central.Reports.FanOut)
+		if err != nil {
+			return nil, err
+		}
+		c.reportsGroup = g
+	}
+	return c.reportsGroup, nil
+}
+
+// BadgesDatastoreGroup builds the fan-out Datastore group for central
+// badges from c.Central.Badges.Datastores, caching it so repeated calls
+// reuse the same backend clients. BuildCentralConfig must have been called
+// first so defaults and the root are resolved.
+func (c *Config) BadgesDatastoreGroup() (*datastore.Group, error) {
+	if c.badgesGroup == nil {
+		g, err := c.buildDatastoreGroup(c.Central.Badges.Datastores, c.Central.Badges.FanOut)
+		if err != nil {
+			return nil, err
+		}
+		c.badgesGroup = g
+	}
+	return c.badgesGroup, nil
+}
+
+func (c *Config) buildDatastoreGroup(urls []string, fanOut string) (*datastore.Group, error) {
+	stores := make([]datastore.Datastore, 0, len(urls))
+	for _, u := range urls {
+		s, err := datastore.New(u, datastore.WithRoot(c.Central.Root))
+		if err != nil {
+			return nil, err
+		}
+		stores = append(stores, s)
+	}
+	return datastore.NewGroup(stores, datastore.FanOutMode(fanOut)), nil
+}
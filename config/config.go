@@ -5,19 +5,18 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/antonmedv/expr"
 	"github.com/goccy/go-yaml"
-	"github.com/k1LoW/duration"
 	"github.com/k1LoW/expand"
+	"github.com/k1LoW/octocov/datastore"
 	"github.com/k1LoW/octocov/gh"
 	"github.com/k1LoW/octocov/report"
 )
 
-const defaultBadgesDatastore = "local://reports"
+const defaultBadgesDatastore = "local://badges"
 const defaultReportsDatastore = "local://reports"
 
 const (
@@ -47,12 +46,28 @@ type Config struct {
 	// config file path
 	path string
 	gh   *gh.Gh
+	// reportsGroup and badgesGroup cache the fan-out Datastore groups built
+	// by ReportsDatastoreGroup / BadgesDatastoreGroup so repeated calls
+	// (e.g. PushReport and PushBadge) don't reconstruct backend clients.
+	// Trend acceptance does not use them; it builds its own datastore.
+	reportsGroup *datastore.Group
+	badgesGroup  *datastore.Group
 }
 
 type ConfigCoverage struct {
 	Path       string              `yaml:"path,omitempty"`
 	Badge      ConfigCoverageBadge `yaml:"badge,omitempty"`
 	Acceptable string              `yaml:"acceptable,omitempty"`
+	// AcceptablePatterns enforces stricter (or looser) coverage on subtrees
+	// matched by a doublestar glob, e.g. "internal/critical/**", in
+	// addition to the global Acceptable threshold.
+	AcceptablePatterns []ConfigCoverageAcceptablePattern `yaml:"acceptablePatterns,omitempty"`
+	Trend              *ConfigTrend                      `yaml:"trend,omitempty"`
+}
+
+type ConfigCoverageAcceptablePattern struct {
+	Path       string `yaml:"path"`
+	Acceptable string `yaml:"acceptable"`
 }
 
 type ConfigCoverageBadge struct {
@@ -64,6 +79,7 @@ type ConfigCodeToTestRatio struct {
 	Test       []string                   `yaml:"test"`
 	Badge      ConfigCodeToTestRatioBadge `yaml:"badge,omitempty"`
 	Acceptable string                     `yaml:"acceptable,omitempty"`
+	Trend      *ConfigTrend               `yaml:"trend,omitempty"`
 }
 
 type ConfigCodeToTestRatioBadge struct {
@@ -74,6 +90,7 @@ type ConfigTestExecutionTime struct {
 	Badge      ConfigTestExecutionTimeBadge `yaml:"badge,omitempty"`
 	Acceptable string                       `yaml:"acceptable,omitempty"`
 	Steps      []string                     `yaml:"steps,omitempty"`
+	Trend      *ConfigTrend                 `yaml:"trend,omitempty"`
 }
 
 type ConfigTestExecutionTimeBadge struct {
@@ -87,14 +104,37 @@ type ConfigCentral struct {
 	Badges  ConfigCentralBadges  `yaml:"badges"`
 	Push    *ConfigPush          `yaml:"push"`
 	If      string               `yaml:"if,omitempty"`
+	Sign    *ConfigCentralSign   `yaml:"sign,omitempty"`
+}
+
+type ConfigCentralSign struct {
+	// Keyless signs/verifies with cosign's Sigstore keyless flow,
+	// authenticated via the CI's OIDC identity token. Mutually exclusive
+	// with KeyPath.
+	Keyless bool `yaml:"keyless,omitempty"`
+	// KeyPath is a local ed25519 private key (PEM) used to sign pushed
+	// artifacts instead of keyless signing.
+	KeyPath string `yaml:"keyPath,omitempty"`
+	// PublicKeyPath is the ed25519 public key (PEM) used to verify
+	// artifacts signed with KeyPath.
+	PublicKeyPath string `yaml:"publicKeyPath,omitempty"`
+	// CertIdentity and CertOIDCIssuer pin the expected signer identity
+	// when verifying a Keyless signature.
+	CertIdentity   string `yaml:"certIdentity,omitempty"`
+	CertOIDCIssuer string `yaml:"certOidcIssuer,omitempty"`
 }
 
 type ConfigCentralReports struct {
 	Datastores []string `yaml:"datastores"`
+	// FanOut controls how pushing to multiple Datastores handles a failure:
+	// "all-or-nothing" (default) aborts on the first error, "best-effort"
+	// only fails once every datastore has failed.
+	FanOut string `yaml:"fanOut,omitempty"`
 }
 
 type ConfigCentralBadges struct {
 	Datastores []string `yaml:"datastores"`
+	FanOut     string   `yaml:"fanOut,omitempty"`
 }
 
 type ConfigPush struct {
@@ -165,67 +205,57 @@ func (c *Config) Loaded() bool {
 	return c.path != ""
 }
 
-func (c *Config) Acceptable(r, rPrev *report.Report) error {
+func (c *Config) Acceptable(ctx context.Context, r, rPrev *report.Report) error {
+	var prevCoverage, prevRatio, prevExecTime float64
+	if rPrev != nil {
+		prevCoverage = rPrev.CoveragePercent()
+		prevRatio = rPrev.CodeToTestRatioRatio()
+		prevExecTime = rPrev.TestExecutionTimeNano()
+	}
+
+	ghCtx := c.acceptableGitHubContext()
+	opts := report.New(c.ReportOptions()...)
+
 	if err := c.CoverageConfigReady(); err == nil {
-		if err := coverageAcceptable(r.CoveragePercent(), c.Coverage.Acceptable); err != nil {
+		current := r.CoveragePercent()
+		ok, err := c.acceptable(c.Coverage.Acceptable, current, prevCoverage, normalizeCoverageCond, ghCtx)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("code coverage is %s, which does not meet the accepted condition `%s`", opts.FormatPercent(current), c.Coverage.Acceptable)
+		}
+		if err := c.coveragePatternsAcceptable(r, rPrev, ghCtx, opts); err != nil {
 			return err
 		}
 	}
 
 	if err := c.CodeToTestRatioConfigReady(); err == nil {
-		if err := codeToTestRatioAcceptable(r.CodeToTestRatioRatio(), c.CodeToTestRatio.Acceptable); err != nil {
+		current := r.CodeToTestRatioRatio()
+		ok, err := c.acceptable(c.CodeToTestRatio.Acceptable, current, prevRatio, normalizeRatioCond, ghCtx)
+		if err != nil {
 			return err
 		}
+		if !ok {
+			return fmt.Errorf("code to test ratio is 1:%.1f, which does not meet the accepted condition `%s`", current, c.CodeToTestRatio.Acceptable)
+		}
 	}
 
 	if err := c.TestExecutionTimeConfigReady(); err == nil {
-		if err := testExecutionTimeAcceptable(r.TestExecutionTimeNano(), c.TestExecutionTime.Acceptable); err != nil {
+		current := r.TestExecutionTimeNano()
+		ok, err := c.acceptable(c.TestExecutionTime.Acceptable, current, prevExecTime, normalizeExecutionTimeCond, ghCtx)
+		if err != nil {
 			return err
 		}
+		if !ok {
+			return fmt.Errorf("test execution time is %s, which does not meet the accepted condition `%s`", opts.FormatDuration(time.Duration(current)), c.TestExecutionTime.Acceptable)
+		}
 	}
 
-	return nil
-}
-
-func coverageAcceptable(cov float64, cond string) error {
-	if cond == "" {
-		return nil
-	}
-	a, err := strconv.ParseFloat(strings.TrimSuffix(cond, "%"), 64)
-	if err != nil {
-		return err
-	}
-	if cov < a {
-		return fmt.Errorf("code coverage is %.1f%%, which is below the accepted %.1f%%", cov, a)
-	}
-	return nil
-}
-
-func codeToTestRatioAcceptable(ratio float64, cond string) error {
-	if cond == "" {
-		return nil
-	}
-	a, err := strconv.ParseFloat(strings.TrimPrefix(cond, "1:"), 64)
-	if err != nil {
+	if err := c.TrendAcceptable(ctx, r); err != nil {
 		return err
 	}
-	if ratio < a {
-		return fmt.Errorf("code to test ratio is 1:%.1f, which is below the accepted 1:%.1f", ratio, a)
-	}
-	return nil
-}
 
-func testExecutionTimeAcceptable(t float64, cond string) error {
-	if cond == "" {
-		return nil
-	}
-	a, err := duration.Parse(cond)
-	if err != nil {
-		return err
-	}
-	if t > float64(a) {
-		return fmt.Errorf("test execution time is %v, which is above the accepted %v", time.Duration(t), a)
-	}
 	return nil
 }
 
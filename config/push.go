@@ -0,0 +1,65 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/k1LoW/octocov/datastore"
+	"github.com/k1LoW/octocov/report"
+)
+
+// PushReport stores r as the central "latest" report and, alongside it, a
+// timestamped snapshot under datastore.HistoryPrefix, so ConfigTrend has an
+// actual series to read back through datastore.History instead of a single
+// ever-overwritten report. When central.sign is configured, a detached
+// signature is pushed alongside both artifacts.
+func (c *Config) PushReport(ctx context.Context, r *report.Report) error {
+	group, err := c.ReportsDatastoreGroup()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("push report: %w", err)
+	}
+	if err := c.putSigned(ctx, group, datastore.ReportFileName, data); err != nil {
+		return fmt.Errorf("push report: %w", err)
+	}
+	snapshot := fmt.Sprintf("%s/%d-%s", datastore.HistoryPrefix, time.Now().UTC().Unix(), datastore.ReportFileName)
+	if err := c.putSigned(ctx, group, snapshot, data); err != nil {
+		return fmt.Errorf("push report: snapshot: %w", err)
+	}
+	return nil
+}
+
+// PushBadge stores data (a badge SVG) at name in the central badges
+// datastore, signing it alongside when central.sign is configured.
+func (c *Config) PushBadge(ctx context.Context, name string, data []byte) error {
+	group, err := c.BadgesDatastoreGroup()
+	if err != nil {
+		return err
+	}
+	if err := c.putSigned(ctx, group, name, data); err != nil {
+		return fmt.Errorf("push badge: %w", err)
+	}
+	return nil
+}
+
+// putSigned puts data at path in group and, when central.sign is
+// configured, signs data and puts the detached signature at "<path>.sig"
+// alongside it.
+func (c *Config) putSigned(ctx context.Context, group *datastore.Group, path string, data []byte) error {
+	if err := group.Put(ctx, path, data); err != nil {
+		return err
+	}
+	if c.Central == nil || c.Central.Sign == nil {
+		return nil
+	}
+	sig, err := c.SignReport(ctx, data)
+	if err != nil {
+		return fmt.Errorf("sign %q: %w", path, err)
+	}
+	return group.Put(ctx, path+".sig", sig)
+}
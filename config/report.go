@@ -0,0 +1,34 @@
+package config
+
+import (
+	"github.com/k1LoW/octocov/report"
+)
+
+type ConfigReport struct {
+	// Locale is a BCP 47 language tag (e.g. "ja-JP", "en-US") used to
+	// render numbers, percentages, and durations. When unset it falls back
+	// to the LC_ALL / LANG env vars.
+	//
+	// This package only wires it into Config.Acceptable's own
+	// acceptable-condition error messages today (coverage percentage and
+	// test execution time formatting; the code to test ratio is not
+	// localized). The comment body, badge labels, and table rendering
+	// octocov also produces live outside this package; wire
+	// ReportOptions() into those call sites too once they're touched.
+	Locale string `yaml:"locale,omitempty"`
+}
+
+// ReportOptions builds the report.Option values that should be applied to
+// every locale-sensitive rendering path, resolving Report.Locale via
+// report.DetectLocale. See ConfigReport.Locale for which call sites
+// currently consume it.
+func (c *Config) ReportOptions() []report.Option {
+	locale := ""
+	if c.Report != nil {
+		locale = c.Report.Locale
+	}
+	tag := report.DetectLocale(locale)
+	return []report.Option{
+		report.Locale(&tag),
+	}
+}
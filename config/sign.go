@@ -0,0 +1,67 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/k1LoW/octocov/sign"
+)
+
+// SignReport produces a detached signature for data (a pushed report JSON
+// or badge SVG) using the configured central.sign backend, for the push
+// flow to store alongside the artifact.
+func (c *Config) SignReport(ctx context.Context, data []byte) ([]byte, error) {
+	signer, err := c.signer()
+	if err != nil {
+		return nil, err
+	}
+	return signer.Sign(ctx, data)
+}
+
+// VerifyReport validates signature against data using the configured
+// central.sign backend. Callers (the `verify` subcommand, and trend
+// acceptance before it trusts historical reports) are responsible for
+// fetching both the artifact and its "<path>.sig" detached signature from
+// the datastore first.
+func (c *Config) VerifyReport(ctx context.Context, data, signature []byte) error {
+	verifier, err := c.verifier()
+	if err != nil {
+		return err
+	}
+	return verifier.Verify(ctx, data, signature)
+}
+
+func (c *Config) signer() (sign.Signer, error) {
+	if c.Central == nil || c.Central.Sign == nil {
+		return nil, errors.New("central.sign: not set")
+	}
+	s := c.Central.Sign
+	switch {
+	case s.Keyless && s.KeyPath != "":
+		return nil, errors.New("central.sign: keyless and keyPath are mutually exclusive")
+	case s.KeyPath != "":
+		return sign.LoadEd25519Signer(s.KeyPath)
+	case s.Keyless:
+		return sign.CosignKeylessSigner{}, nil
+	default:
+		return nil, errors.New("central.sign: neither keyless nor keyPath is set")
+	}
+}
+
+func (c *Config) verifier() (sign.Verifier, error) {
+	if c.Central == nil || c.Central.Sign == nil {
+		return nil, errors.New("central.sign: not set")
+	}
+	s := c.Central.Sign
+	switch {
+	case s.Keyless && s.PublicKeyPath != "":
+		return nil, errors.New("central.sign: keyless and publicKeyPath are mutually exclusive")
+	case s.PublicKeyPath != "":
+		return sign.LoadEd25519Verifier(s.PublicKeyPath)
+	case s.Keyless:
+		return sign.CosignKeylessVerifier{CertIdentity: s.CertIdentity, CertOIDCIssuer: s.CertOIDCIssuer}, nil
+	default:
+		return nil, fmt.Errorf("central.sign: neither keyless nor publicKeyPath is set")
+	}
+}
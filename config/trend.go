@@ -0,0 +1,156 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/k1LoW/duration"
+	"github.com/k1LoW/octocov/datastore"
+	"github.com/k1LoW/octocov/report"
+)
+
+const defaultTrendWindow = 10
+
+// ConfigTrend closes the gap between a single-value Acceptable threshold
+// and the "no regressions" policy users actually want: it compares the
+// current report against a baseline computed from the last Window reports
+// pulled from the central reports datastore.
+type ConfigTrend struct {
+	// MaxDrop is the maximum regression from the baseline that is still
+	// accepted, in the same syntax as the metric's Acceptable field
+	// ("2%" for coverage, "1:0.1" for code to test ratio, "1m" for test
+	// execution time).
+	MaxDrop string `yaml:"max_drop"`
+	// Window is how many historical reports to pull from the datastore.
+	// Defaults to 10.
+	Window int `yaml:"window,omitempty"`
+	// Baseline selects how the historical window is reduced to a single
+	// comparison value: "median" (default), "min", or "last".
+	Baseline string `yaml:"baseline,omitempty"`
+}
+
+// TrendAcceptable checks ConfigCoverage.Trend, ConfigCodeToTestRatio.Trend
+// and ConfigTestExecutionTime.Trend against history pulled from the
+// configured central reports datastore, failing when r regresses from the
+// baseline by more than each rule's max_drop.
+func (c *Config) TrendAcceptable(ctx context.Context, r *report.Report) error {
+	if err := c.CoverageConfigReady(); err == nil && c.Coverage.Trend != nil {
+		current := r.CoveragePercent()
+		baseline, err := c.trendBaseline(ctx, c.Coverage.Trend, func(r *report.Report) float64 { return r.CoveragePercent() })
+		if err != nil {
+			return fmt.Errorf("coverage.trend: %w", err)
+		}
+		maxDrop, err := strconv.ParseFloat(strings.TrimSuffix(c.Coverage.Trend.MaxDrop, "%"), 64)
+		if err != nil {
+			return fmt.Errorf("coverage.trend.max_drop: %w", err)
+		}
+		if baseline-current > maxDrop {
+			return fmt.Errorf("code coverage is %.1f%%, a drop of %.1f%% from the %s baseline of %.1f%% (max allowed drop %.1f%%)", current, baseline-current, trendBaselineName(c.Coverage.Trend.Baseline), baseline, maxDrop)
+		}
+	}
+
+	if err := c.CodeToTestRatioConfigReady(); err == nil && c.CodeToTestRatio.Trend != nil {
+		current := r.CodeToTestRatioRatio()
+		baseline, err := c.trendBaseline(ctx, c.CodeToTestRatio.Trend, func(r *report.Report) float64 { return r.CodeToTestRatioRatio() })
+		if err != nil {
+			return fmt.Errorf("codeToTestRatio.trend: %w", err)
+		}
+		maxDrop, err := strconv.ParseFloat(strings.TrimPrefix(c.CodeToTestRatio.Trend.MaxDrop, "1:"), 64)
+		if err != nil {
+			return fmt.Errorf("codeToTestRatio.trend.max_drop: %w", err)
+		}
+		if baseline-current > maxDrop {
+			return fmt.Errorf("code to test ratio is 1:%.1f, a drop of 1:%.1f from the %s baseline of 1:%.1f (max allowed drop 1:%.1f)", current, baseline-current, trendBaselineName(c.CodeToTestRatio.Trend.Baseline), baseline, maxDrop)
+		}
+	}
+
+	if err := c.TestExecutionTimeConfigReady(); err == nil && c.TestExecutionTime.Trend != nil {
+		current := r.TestExecutionTimeNano()
+		baseline, err := c.trendBaseline(ctx, c.TestExecutionTime.Trend, func(r *report.Report) float64 { return r.TestExecutionTimeNano() })
+		if err != nil {
+			return fmt.Errorf("testExecutionTime.trend: %w", err)
+		}
+		maxDrop, err := duration.Parse(c.TestExecutionTime.Trend.MaxDrop)
+		if err != nil {
+			return fmt.Errorf("testExecutionTime.trend.max_drop: %w", err)
+		}
+		if current-baseline > float64(maxDrop) {
+			return fmt.Errorf("test execution time is %v, %v slower than the %s baseline of %v (max allowed increase %v)", time.Duration(current), time.Duration(current-baseline), trendBaselineName(c.TestExecutionTime.Trend.Baseline), time.Duration(baseline), maxDrop)
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) trendBaseline(ctx context.Context, t *ConfigTrend, extract func(*report.Report) float64) (float64, error) {
+	if !c.CentralConfigReady() {
+		return 0, errors.New("trend acceptance requires a configured `central` datastore")
+	}
+	datastores := c.Central.Reports.Datastores
+	if len(datastores) == 0 {
+		datastores = []string{defaultReportsDatastore}
+	}
+	store, err := datastore.New(datastores[0], datastore.WithRoot(c.Central.Root))
+	if err != nil {
+		return 0, err
+	}
+	window := t.Window
+	if window <= 0 {
+		window = defaultTrendWindow
+	}
+	var verify func(data, signature []byte) error
+	if c.Central.Sign != nil {
+		verify = func(data, signature []byte) error { return c.VerifyReport(ctx, data, signature) }
+	}
+	reports, err := datastore.History(ctx, store, datastore.HistoryPrefix, window, verify)
+	if err != nil {
+		return 0, err
+	}
+	if len(reports) == 0 {
+		return 0, errors.New("no historical reports found in the datastore")
+	}
+	values := make([]float64, 0, len(reports))
+	for _, r := range reports {
+		values = append(values, extract(r))
+	}
+	switch t.Baseline {
+	case "min":
+		return minFloat(values), nil
+	case "last":
+		return values[len(values)-1], nil
+	default:
+		return medianFloat(values), nil
+	}
+}
+
+func trendBaselineName(b string) string {
+	if b == "" {
+		return "median"
+	}
+	return b
+}
+
+func minFloat(values []float64) float64 {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+func medianFloat(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
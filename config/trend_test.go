@@ -0,0 +1,37 @@
+package config
+
+import "testing"
+
+func TestMedianFloat(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{"odd length", []float64{3, 1, 2}, 2},
+		{"even length", []float64{4, 1, 3, 2}, 2.5},
+		{"single value", []float64{5}, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := medianFloat(tt.values); got != tt.want {
+				t.Errorf("medianFloat(%v) = %v, want %v", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMinFloat(t *testing.T) {
+	if got := minFloat([]float64{5, 1, 3}); got != 1 {
+		t.Errorf("minFloat() = %v, want 1", got)
+	}
+}
+
+func TestTrendBaselineName(t *testing.T) {
+	if got := trendBaselineName(""); got != "median" {
+		t.Errorf("trendBaselineName(\"\") = %q, want %q", got, "median")
+	}
+	if got := trendBaselineName("min"); got != "min" {
+		t.Errorf("trendBaselineName(\"min\") = %q, want %q", got, "min")
+	}
+}
@@ -0,0 +1,93 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+)
+
+// azblobDatastore stores artifacts in an Azure Blob Storage container.
+// Credentials are resolved from the AZURE_STORAGE_ACCOUNT and
+// AZURE_STORAGE_KEY env vars, the standard pair for shared-key auth.
+type azblobDatastore struct {
+	client    *azblob.Client
+	container string
+	prefix    string
+}
+
+func newAzblobDatastore(u *url.URL) (Datastore, error) {
+	container := u.Host
+	if container == "" {
+		return nil, fmt.Errorf("azblob datastore: container not set in %q", u.String())
+	}
+	account := os.Getenv("AZURE_STORAGE_ACCOUNT")
+	key := os.Getenv("AZURE_STORAGE_KEY")
+	if account == "" || key == "" {
+		return nil, fmt.Errorf("azblob datastore: AZURE_STORAGE_ACCOUNT and AZURE_STORAGE_KEY must be set")
+	}
+	cred, err := azblob.NewSharedKeyCredential(account, key)
+	if err != nil {
+		return nil, fmt.Errorf("azblob datastore: %w", err)
+	}
+	client, err := azblob.NewClientWithSharedKeyCredential(fmt.Sprintf("https://%s.blob.core.windows.net/", account), cred, nil)
+	if err != nil {
+		return nil, fmt.Errorf("azblob datastore: %w", err)
+	}
+	return &azblobDatastore{
+		client:    client,
+		container: container,
+		prefix:    strings.Trim(u.Path, "/"),
+	}, nil
+}
+
+func (d *azblobDatastore) key(path string) string {
+	if d.prefix == "" {
+		return path
+	}
+	return d.prefix + "/" + path
+}
+
+func (d *azblobDatastore) Put(ctx context.Context, path string, data []byte) error {
+	_, err := d.client.UploadBuffer(ctx, d.container, d.key(path), data, nil)
+	return err
+}
+
+func (d *azblobDatastore) Get(ctx context.Context, path string) ([]byte, error) {
+	resp, err := d.client.DownloadStream(ctx, d.container, d.key(path), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+func (d *azblobDatastore) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	p := d.client.NewListBlobsFlatPager(d.container, &azblob.ListBlobsFlatOptions{
+		Prefix: toStrPtr(d.key(prefix)),
+	})
+	for p.More() {
+		page, err := p.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range page.Segment.BlobItems {
+			keys = append(keys, strings.TrimPrefix(*b.Name, d.prefix+"/"))
+		}
+	}
+	return keys, nil
+}
+
+func (d *azblobDatastore) Delete(ctx context.Context, path string) error {
+	_, err := d.client.DeleteBlob(ctx, d.container, d.key(path), nil)
+	return err
+}
+
+func toStrPtr(s string) *string {
+	return &s
+}
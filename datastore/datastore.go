@@ -0,0 +1,88 @@
+// Package datastore provides the storage backends that octocov can push
+// central reports and badges to, and read them back from.
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Datastore is a storage backend capable of holding report JSON and badge
+// SVG artifacts.
+type Datastore interface {
+	// Put stores data at path, creating or overwriting it.
+	Put(ctx context.Context, path string, data []byte) error
+	// Get returns the contents stored at path.
+	Get(ctx context.Context, path string) ([]byte, error)
+	// List returns the paths stored under prefix.
+	List(ctx context.Context, prefix string) ([]string, error)
+	// Delete removes the object at path.
+	Delete(ctx context.Context, path string) error
+}
+
+// Option configures a Datastore constructed by New.
+type Option func(*options)
+
+type options struct {
+	root string
+}
+
+// WithRoot sets the base directory that relative `local://` URLs are
+// resolved against.
+func WithRoot(root string) Option {
+	return func(o *options) {
+		o.root = root
+	}
+}
+
+// New builds a Datastore for u, dispatching on its URL scheme:
+//
+//	local://path                      local filesystem, relative to WithRoot
+//	s3://bucket/prefix                Amazon S3
+//	gs://bucket/prefix                Google Cloud Storage
+//	azblob://container/prefix         Azure Blob Storage
+//	github://owner/repo/branch/path   a GitHub repository
+func New(u string, opts ...Option) (Datastore, error) {
+	var o options
+	for _, apply := range opts {
+		apply(&o)
+	}
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return nil, fmt.Errorf("invalid datastore url %q: %w", u, err)
+	}
+	switch parsed.Scheme {
+	case "local":
+		return newLocalDatastore(parsed, o)
+	case "s3":
+		return newS3Datastore(parsed)
+	case "gs":
+		return newGCSDatastore(parsed)
+	case "azblob":
+		return newAzblobDatastore(parsed)
+	case "github":
+		return newGitHubDatastore(parsed)
+	default:
+		return nil, fmt.Errorf("unsupported datastore scheme %q in %q", parsed.Scheme, u)
+	}
+}
+
+// ValidateURL checks that u is a well-formed Datastore URL with a supported
+// scheme, without constructing the backend behind it. Constructing a
+// backend (via New) can require credentials and network access (a
+// `github://` client needs GITHUB_TOKEN, an `s3://` client resolves AWS
+// credentials, ...), so config validation that only needs to catch typos
+// and unsupported schemes should call this instead.
+func ValidateURL(u string) error {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return fmt.Errorf("invalid datastore url %q: %w", u, err)
+	}
+	switch parsed.Scheme {
+	case "local", "s3", "gs", "azblob", "github":
+		return nil
+	default:
+		return fmt.Errorf("unsupported datastore scheme %q in %q", parsed.Scheme, u)
+	}
+}
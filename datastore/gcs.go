@@ -0,0 +1,82 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+)
+
+// gcsDatastore stores artifacts in a Google Cloud Storage bucket.
+// Credentials are resolved the standard way: GOOGLE_APPLICATION_CREDENTIALS
+// env var, or the ambient metadata-server credentials when running on GCP.
+type gcsDatastore struct {
+	client *storage.Client
+	bucket string
+	prefix string
+}
+
+func newGCSDatastore(u *url.URL) (Datastore, error) {
+	bucket := u.Host
+	if bucket == "" {
+		return nil, fmt.Errorf("gs datastore: bucket not set in %q", u.String())
+	}
+	client, err := storage.NewClient(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("gs datastore: %w", err)
+	}
+	return &gcsDatastore{
+		client: client,
+		bucket: bucket,
+		prefix: strings.Trim(u.Path, "/"),
+	}, nil
+}
+
+func (d *gcsDatastore) key(path string) string {
+	if d.prefix == "" {
+		return path
+	}
+	return d.prefix + "/" + path
+}
+
+func (d *gcsDatastore) Put(ctx context.Context, path string, data []byte) error {
+	w := d.client.Bucket(d.bucket).Object(d.key(path)).NewWriter(ctx)
+	if _, err := w.Write(data); err != nil {
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+func (d *gcsDatastore) Get(ctx context.Context, path string) ([]byte, error) {
+	r, err := d.client.Bucket(d.bucket).Object(d.key(path)).NewReader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func (d *gcsDatastore) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	it := d.client.Bucket(d.bucket).Objects(ctx, &storage.Query{Prefix: d.key(prefix)})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, strings.TrimPrefix(attrs.Name, d.prefix+"/"))
+	}
+	return keys, nil
+}
+
+func (d *gcsDatastore) Delete(ctx context.Context, path string) error {
+	return d.client.Bucket(d.bucket).Object(d.key(path)).Delete(ctx)
+}
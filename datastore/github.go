@@ -0,0 +1,118 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	gogithub "github.com/google/go-github/v55/github"
+	"golang.org/x/oauth2"
+)
+
+// githubDatastore stores artifacts as files in a GitHub repository, for
+// teams that already keep a central reports repo and don't want a separate
+// cloud bucket. The URL shape is github://owner/repo/branch/path. Auth
+// comes from GITHUB_TOKEN (or GITHUB_ACCESS_TOKEN), the same env vars
+// octocov's GitHub client uses elsewhere.
+type githubDatastore struct {
+	client *gogithub.Client
+	owner  string
+	repo   string
+	branch string
+	prefix string
+}
+
+func newGitHubDatastore(u *url.URL) (Datastore, error) {
+	parts := strings.SplitN(strings.Trim(u.Path, "/"), "/", 2)
+	if u.Host == "" || parts[0] == "" {
+		return nil, fmt.Errorf("github datastore: expected github://owner/repo/branch/path, got %q", u.String())
+	}
+	owner := u.Host
+	repo := parts[0]
+	branch := "main"
+	prefix := ""
+	if len(parts) == 2 {
+		rest := strings.SplitN(parts[1], "/", 2)
+		branch = rest[0]
+		if len(rest) == 2 {
+			prefix = rest[1]
+		}
+	}
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		token = os.Getenv("GITHUB_ACCESS_TOKEN")
+	}
+	if token == "" {
+		return nil, fmt.Errorf("github datastore: GITHUB_TOKEN (or GITHUB_ACCESS_TOKEN) is not set")
+	}
+	ctx := context.Background()
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+	client := gogithub.NewClient(oauth2.NewClient(ctx, ts))
+	return &githubDatastore{client: client, owner: owner, repo: repo, branch: branch, prefix: prefix}, nil
+}
+
+func (d *githubDatastore) key(path string) string {
+	if d.prefix == "" {
+		return path
+	}
+	return d.prefix + "/" + path
+}
+
+func (d *githubDatastore) Put(ctx context.Context, path string, data []byte) error {
+	key := d.key(path)
+	opts := &gogithub.RepositoryContentFileOptions{
+		Message: gogithub.String(fmt.Sprintf("Update %s", key)),
+		Content: data,
+		Branch:  gogithub.String(d.branch),
+	}
+	if existing, _, _, err := d.client.Repositories.GetContents(ctx, d.owner, d.repo, key, &gogithub.RepositoryContentGetOptions{Ref: d.branch}); err == nil && existing != nil {
+		opts.SHA = existing.SHA
+		_, _, err := d.client.Repositories.UpdateFile(ctx, d.owner, d.repo, key, opts)
+		return err
+	}
+	_, _, err := d.client.Repositories.CreateFile(ctx, d.owner, d.repo, key, opts)
+	return err
+}
+
+func (d *githubDatastore) Get(ctx context.Context, path string) ([]byte, error) {
+	fc, _, _, err := d.client.Repositories.GetContents(ctx, d.owner, d.repo, d.key(path), &gogithub.RepositoryContentGetOptions{Ref: d.branch})
+	if err != nil {
+		return nil, err
+	}
+	content, err := fc.GetContent()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(content), nil
+}
+
+func (d *githubDatastore) List(ctx context.Context, prefix string) ([]string, error) {
+	_, dir, _, err := d.client.Repositories.GetContents(ctx, d.owner, d.repo, d.key(prefix), &gogithub.RepositoryContentGetOptions{Ref: d.branch})
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, f := range dir {
+		if f.GetType() != "file" {
+			continue
+		}
+		paths = append(paths, strings.TrimPrefix(f.GetPath(), d.prefix+"/"))
+	}
+	return paths, nil
+}
+
+func (d *githubDatastore) Delete(ctx context.Context, path string) error {
+	key := d.key(path)
+	existing, _, _, err := d.client.Repositories.GetContents(ctx, d.owner, d.repo, key, &gogithub.RepositoryContentGetOptions{Ref: d.branch})
+	if err != nil {
+		return err
+	}
+	_, _, err = d.client.Repositories.DeleteFile(ctx, d.owner, d.repo, key, &gogithub.RepositoryContentFileOptions{
+		Message: gogithub.String(fmt.Sprintf("Delete %s", key)),
+		SHA:     existing.SHA,
+		Branch:  gogithub.String(d.branch),
+	})
+	return err
+}
@@ -0,0 +1,65 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+)
+
+// FanOutMode controls how a Group reacts when one of its member Datastores
+// fails.
+type FanOutMode string
+
+const (
+	// FanOutAllOrNothing aborts on the first error from any datastore.
+	FanOutAllOrNothing FanOutMode = "all-or-nothing"
+	// FanOutBestEffort pushes to every datastore and only reports an error
+	// once all of them have failed.
+	FanOutBestEffort FanOutMode = "best-effort"
+)
+
+// Group fans a single Put/Delete out to multiple Datastores, e.g. pushing
+// the same report to both an S3 bucket and a central Git repo.
+type Group struct {
+	Stores []Datastore
+	Mode   FanOutMode
+}
+
+// NewGroup builds a Group. An empty mode defaults to FanOutAllOrNothing.
+func NewGroup(stores []Datastore, mode FanOutMode) *Group {
+	if mode == "" {
+		mode = FanOutAllOrNothing
+	}
+	return &Group{Stores: stores, Mode: mode}
+}
+
+func (g *Group) Put(ctx context.Context, path string, data []byte) error {
+	var errs []error
+	for _, s := range g.Stores {
+		if err := s.Put(ctx, path, data); err != nil {
+			if g.Mode == FanOutAllOrNothing {
+				return fmt.Errorf("put %q: %w", path, err)
+			}
+			errs = append(errs, err)
+		}
+	}
+	if len(g.Stores) > 0 && len(errs) == len(g.Stores) {
+		return fmt.Errorf("put %q: all %d datastores failed: %w", path, len(errs), errs[0])
+	}
+	return nil
+}
+
+func (g *Group) Delete(ctx context.Context, path string) error {
+	var errs []error
+	for _, s := range g.Stores {
+		if err := s.Delete(ctx, path); err != nil {
+			if g.Mode == FanOutAllOrNothing {
+				return fmt.Errorf("delete %q: %w", path, err)
+			}
+			errs = append(errs, err)
+		}
+	}
+	if len(g.Stores) > 0 && len(errs) == len(g.Stores) {
+		return fmt.Errorf("delete %q: all %d datastores failed: %w", path, len(errs), errs[0])
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakeDatastore is an in-memory Datastore stub for exercising Group without
+// touching any real backend.
+type fakeDatastore struct {
+	putErr error
+	puts   int
+}
+
+func (f *fakeDatastore) Put(_ context.Context, _ string, _ []byte) error {
+	f.puts++
+	return f.putErr
+}
+
+func (f *fakeDatastore) Get(_ context.Context, _ string) ([]byte, error) { return nil, nil }
+
+func (f *fakeDatastore) List(_ context.Context, _ string) ([]string, error) { return nil, nil }
+
+func (f *fakeDatastore) Delete(_ context.Context, _ string) error { return nil }
+
+func TestGroupPutAllOrNothing(t *testing.T) {
+	ok := &fakeDatastore{}
+	failing := &fakeDatastore{putErr: errors.New("boom")}
+	g := NewGroup([]Datastore{ok, failing}, FanOutAllOrNothing)
+	if err := g.Put(context.Background(), "report.json", []byte("{}")); err == nil {
+		t.Fatal("expected an error when one datastore fails in all-or-nothing mode")
+	}
+}
+
+func TestGroupPutBestEffort(t *testing.T) {
+	ok := &fakeDatastore{}
+	failing := &fakeDatastore{putErr: errors.New("boom")}
+	g := NewGroup([]Datastore{ok, failing}, FanOutBestEffort)
+	if err := g.Put(context.Background(), "report.json", []byte("{}")); err != nil {
+		t.Fatalf("expected best-effort to tolerate one failing datastore, got %v", err)
+	}
+	if ok.puts != 1 {
+		t.Errorf("expected the healthy datastore to be written to, puts = %d", ok.puts)
+	}
+}
+
+func TestGroupPutBestEffortAllFail(t *testing.T) {
+	a := &fakeDatastore{putErr: errors.New("boom a")}
+	b := &fakeDatastore{putErr: errors.New("boom b")}
+	g := NewGroup([]Datastore{a, b}, FanOutBestEffort)
+	if err := g.Put(context.Background(), "report.json", []byte("{}")); err == nil {
+		t.Fatal("expected an error when every datastore fails in best-effort mode")
+	}
+}
+
+func TestNewGroupDefaultsToAllOrNothing(t *testing.T) {
+	g := NewGroup(nil, "")
+	if g.Mode != FanOutAllOrNothing {
+		t.Errorf("NewGroup default mode = %q, want %q", g.Mode, FanOutAllOrNothing)
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"local://reports", false},
+		{"s3://bucket/prefix", false},
+		{"gs://bucket/prefix", false},
+		{"azblob://container/prefix", false},
+		{"github://owner/repo/branch/path", false},
+		{"ftp://nope", true},
+		{"://bad", true},
+	}
+	for _, tt := range tests {
+		err := ValidateURL(tt.url)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+		}
+	}
+}
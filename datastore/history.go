@@ -0,0 +1,84 @@
+package datastore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/k1LoW/octocov/report"
+)
+
+// ReportFileName is the name report JSON artifacts are pushed under in a
+// central datastore. Reports and badges default to separate datastores,
+// but a config can still point both at the same URL, so History filters
+// List's results down to report files before unmarshalling rather than
+// assuming the datastore holds nothing else.
+const ReportFileName = "report.json"
+
+// HistoryPrefix is the path snapshots of historical reports are pushed
+// under (see Config.PushReport), as opposed to the single always-current
+// report living at the datastore root. History reads this prefix back so
+// the "latest" report and its own history series don't get mixed together.
+const HistoryPrefix = "history"
+
+// History returns the last n reports stored under prefix, oldest first.
+// Backends are expected to keep report paths lexically sortable (e.g. a
+// date or incrementing ref in the name), the same assumption the central
+// reports layout already makes (Config.PushReport names each snapshot with
+// a leading Unix timestamp for exactly this reason). n <= 0 returns the
+// full history.
+//
+// If verify is non-nil, each report's "<path>.sig" detached signature is
+// fetched from the same datastore and checked before the report is
+// trusted; a report with a missing or failing signature is rejected
+// outright rather than silently skipped, since that would let a tampered
+// history pass unnoticed.
+func History(ctx context.Context, d Datastore, prefix string, n int, verify func(data, signature []byte) error) ([]*report.Report, error) {
+	all, err := d.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(all))
+	for _, p := range all {
+		// List also returns each report's detached ".sig" signature file
+		// (e.g. "report.json.sig") alongside it; excluding it explicitly
+		// keeps this loop correct even if ReportFileName's matching rule
+		// changes, since treating a signature as a report would make
+		// verification look for "report.json.sig.sig" and fail outright.
+		if strings.HasSuffix(p, ".sig") {
+			continue
+		}
+		if !strings.HasSuffix(p, ReportFileName) {
+			continue
+		}
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	if n > 0 && len(paths) > n {
+		paths = paths[len(paths)-n:]
+	}
+	reports := make([]*report.Report, 0, len(paths))
+	for _, p := range paths {
+		b, err := d.Get(ctx, p)
+		if err != nil {
+			return nil, fmt.Errorf("history: get %q: %w", p, err)
+		}
+		if verify != nil {
+			sig, err := d.Get(ctx, p+".sig")
+			if err != nil {
+				return nil, fmt.Errorf("history: %q has no signature to verify: %w", p, err)
+			}
+			if err := verify(b, sig); err != nil {
+				return nil, fmt.Errorf("history: %q failed signature verification: %w", p, err)
+			}
+		}
+		r := &report.Report{}
+		if err := json.Unmarshal(b, r); err != nil {
+			return nil, fmt.Errorf("history: unmarshal %q: %w", p, err)
+		}
+		reports = append(reports, r)
+	}
+	return reports, nil
+}
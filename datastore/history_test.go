@@ -0,0 +1,97 @@
+package datastore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/k1LoW/octocov/report"
+)
+
+func TestHistoryFiltersNonReportFiles(t *testing.T) {
+	dir := t.TempDir()
+	d := &localDatastore{dir: dir}
+	ctx := context.Background()
+
+	putReport := func(path string) {
+		b, err := json.Marshal(&report.Report{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := d.Put(ctx, path, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	putReport("history/1-report.json")
+	putReport("history/2-report.json")
+	if err := d.Put(ctx, "history/coverage.svg", []byte("<svg></svg>")); err != nil {
+		t.Fatal(err)
+	}
+
+	reports, err := History(ctx, d, "history", 0, nil)
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(reports) != 2 {
+		t.Fatalf("History() returned %d reports, want 2 (badge SVG should be filtered out)", len(reports))
+	}
+}
+
+func TestHistoryExcludesSignatures(t *testing.T) {
+	dir := t.TempDir()
+	d := &localDatastore{dir: dir}
+	ctx := context.Background()
+
+	b, err := json.Marshal(&report.Report{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Put(ctx, "history/1-report.json", b); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Put(ctx, "history/1-report.json.sig", []byte("sig")); err != nil {
+		t.Fatal(err)
+	}
+
+	verifyCalls := 0
+	verify := func(_, signature []byte) error {
+		verifyCalls++
+		if string(signature) != "sig" {
+			t.Errorf("verify got signature %q, want %q", signature, "sig")
+		}
+		return nil
+	}
+	reports, err := History(ctx, d, "history", 0, verify)
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(reports) != 1 {
+		t.Fatalf("History() returned %d reports, want 1 (signature file should not be treated as a report)", len(reports))
+	}
+	if verifyCalls != 1 {
+		t.Errorf("verify called %d times, want 1", verifyCalls)
+	}
+}
+
+func TestHistoryWindow(t *testing.T) {
+	dir := t.TempDir()
+	d := &localDatastore{dir: dir}
+	ctx := context.Background()
+	for i := 1; i <= 3; i++ {
+		b, err := json.Marshal(&report.Report{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := d.Put(ctx, fmt.Sprintf("history/%d-report.json", i), b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reports, err := History(ctx, d, "history", 2, nil)
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(reports) != 2 {
+		t.Fatalf("History() returned %d reports, want 2", len(reports))
+	}
+}
@@ -0,0 +1,66 @@
+package datastore
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// localDatastore stores artifacts on the local filesystem, rooted at the
+// `local://` URL's host+path, resolved against WithRoot when relative.
+type localDatastore struct {
+	dir string
+}
+
+func newLocalDatastore(u *url.URL, o options) (Datastore, error) {
+	p := filepath.Join(u.Host, u.Path)
+	if !filepath.IsAbs(p) && o.root != "" {
+		p = filepath.Join(o.root, p)
+	}
+	return &localDatastore{dir: p}, nil
+}
+
+func (d *localDatastore) Put(_ context.Context, path string, data []byte) error {
+	full := filepath.Join(d.dir, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(full, data, 0644)
+}
+
+func (d *localDatastore) Get(_ context.Context, path string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(d.dir, filepath.FromSlash(path)))
+}
+
+func (d *localDatastore) List(_ context.Context, prefix string) ([]string, error) {
+	base := filepath.Join(d.dir, filepath.FromSlash(prefix))
+	var paths []string
+	err := filepath.Walk(base, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(d.dir, p)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func (d *localDatastore) Delete(_ context.Context, path string) error {
+	return os.Remove(filepath.Join(d.dir, filepath.FromSlash(path)))
+}
@@ -0,0 +1,94 @@
+package datastore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// s3Datastore stores artifacts in an Amazon S3 bucket. Credentials are
+// resolved the standard AWS way: AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY /
+// AWS_SESSION_TOKEN env vars, the shared config/credentials files, or the
+// instance/task role.
+type s3Datastore struct {
+	client *s3.Client
+	bucket string
+	prefix string
+}
+
+func newS3Datastore(u *url.URL) (Datastore, error) {
+	bucket := u.Host
+	if bucket == "" {
+		return nil, fmt.Errorf("s3 datastore: bucket not set in %q", u.String())
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("s3 datastore: %w", err)
+	}
+	return &s3Datastore{
+		client: s3.NewFromConfig(cfg),
+		bucket: bucket,
+		prefix: strings.Trim(u.Path, "/"),
+	}, nil
+}
+
+func (d *s3Datastore) key(path string) string {
+	if d.prefix == "" {
+		return path
+	}
+	return d.prefix + "/" + path
+}
+
+func (d *s3Datastore) Put(ctx context.Context, path string, data []byte) error {
+	_, err := d.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(d.bucket),
+		Key:    aws.String(d.key(path)),
+		Body:   bytes.NewReader(data),
+	})
+	return err
+}
+
+func (d *s3Datastore) Get(ctx context.Context, path string) ([]byte, error) {
+	out, err := d.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(d.bucket),
+		Key:    aws.String(d.key(path)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer out.Body.Close()
+	return io.ReadAll(out.Body)
+}
+
+func (d *s3Datastore) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	p := s3.NewListObjectsV2Paginator(d.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(d.bucket),
+		Prefix: aws.String(d.key(prefix)),
+	})
+	for p.HasMorePages() {
+		page, err := p.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range page.Contents {
+			keys = append(keys, strings.TrimPrefix(aws.ToString(obj.Key), d.prefix+"/"))
+		}
+	}
+	return keys, nil
+}
+
+func (d *s3Datastore) Delete(ctx context.Context, path string) error {
+	_, err := d.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(d.bucket),
+		Key:    aws.String(d.key(path)),
+	})
+	return err
+}
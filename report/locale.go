@@ -0,0 +1,71 @@
+package report
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// DetectLocale resolves the locale a report should render with. An
+// explicit BCP 47 tag (e.g. "ja-JP") takes precedence; otherwise it falls
+// back to the LC_ALL / LANG env vars (e.g. "ja_JP.UTF-8"), and finally to
+// language.AmericanEnglish when nothing is set or parseable.
+func DetectLocale(tag string) language.Tag {
+	if tag != "" {
+		if t, err := language.Parse(tag); err == nil {
+			return t
+		}
+	}
+	for _, env := range []string{"LC_ALL", "LANG"} {
+		v := os.Getenv(env)
+		if v == "" {
+			continue
+		}
+		v = strings.SplitN(v, ".", 2)[0]
+		v = strings.ReplaceAll(v, "_", "-")
+		if t, err := language.Parse(v); err == nil {
+			return t
+		}
+	}
+	return language.AmericanEnglish
+}
+
+// Printer returns a message.Printer for o's locale, defaulting to
+// language.AmericanEnglish when no locale is set.
+func (o *Options) Printer() *message.Printer {
+	tag := language.AmericanEnglish
+	if o.Locale != nil {
+		tag = *o.Locale
+	}
+	return message.NewPrinter(tag)
+}
+
+// FormatPercent formats v (e.g. 82.345) as a locale-aware percentage
+// string.
+func (o *Options) FormatPercent(v float64) string {
+	return o.Printer().Sprintf("%.1f%%", v)
+}
+
+// FormatCount formats v as a locale-aware integer, e.g. with the comma or
+// period grouping the locale expects.
+func (o *Options) FormatCount(v int) string {
+	return o.Printer().Sprintf("%d", v)
+}
+
+// FormatDuration formats d as a locale-aware duration string, scaling the
+// unit (seconds, minutes, hours) to keep the number readable and applying
+// the locale's decimal mark.
+func (o *Options) FormatDuration(d time.Duration) string {
+	p := o.Printer()
+	switch {
+	case d < time.Minute:
+		return p.Sprintf("%.1fs", d.Seconds())
+	case d < time.Hour:
+		return p.Sprintf("%.1fm", d.Minutes())
+	default:
+		return p.Sprintf("%.1fh", d.Hours())
+	}
+}
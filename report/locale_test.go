@@ -0,0 +1,44 @@
+package report
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestDetectLocale(t *testing.T) {
+	tests := []struct {
+		name  string
+		tag   string
+		lcAll string
+		lang  string
+		want  language.Tag
+	}{
+		{"explicit tag wins", "ja-JP", "en_US.UTF-8", "fr_FR.UTF-8", language.MustParse("ja-JP")},
+		{"invalid explicit tag falls back to env", "not-a-tag", "ja_JP.UTF-8", "", language.MustParse("ja-JP")},
+		{"LC_ALL takes precedence over LANG", "", "ja_JP.UTF-8", "fr_FR.UTF-8", language.MustParse("ja-JP")},
+		{"falls back to LANG when LC_ALL unset", "", "", "fr_FR.UTF-8", language.MustParse("fr-FR")},
+		{"defaults to American English when nothing is set", "", "", "", language.AmericanEnglish},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LC_ALL", tt.lcAll)
+			t.Setenv("LANG", tt.lang)
+			got := DetectLocale(tt.tag)
+			if got != tt.want {
+				t.Errorf("DetectLocale(%q) = %v, want %v", tt.tag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptionsFormat(t *testing.T) {
+	tag := language.AmericanEnglish
+	o := New(Locale(&tag))
+	if got := o.FormatPercent(82.345); got != "82.3%" {
+		t.Errorf("FormatPercent() = %q, want %q", got, "82.3%")
+	}
+	if got := o.FormatCount(1234); got != "1,234" {
+		t.Errorf("FormatCount() = %q, want %q", got, "1,234")
+	}
+}
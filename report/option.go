@@ -9,6 +9,16 @@ type Options struct {
 
 type Option func(*Options)
 
+// New builds an Options by applying opts in order, e.g. the ones returned
+// by Config.ReportOptions.
+func New(opts ...Option) *Options {
+	o := &Options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
 func Locale(locale *language.Tag) Option {
 	return func(args *Options) {
 		args.Locale = locale
@@ -0,0 +1,67 @@
+package sign
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// CosignKeylessSigner shells out to the cosign CLI to sign-blob using
+// Sigstore's keyless flow, authenticated via the CI's OIDC identity token
+// (e.g. GitHub Actions' ID token). It requires `cosign` to be on PATH.
+type CosignKeylessSigner struct{}
+
+func (CosignKeylessSigner) Sign(ctx context.Context, data []byte) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "cosign", "sign-blob", "--yes", "-")
+	cmd.Stdin = bytes.NewReader(data)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("cosign sign-blob: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// CosignKeylessVerifier shells out to `cosign verify-blob` to validate a
+// keyless signature's Sigstore provenance (Fulcio certificate + Rekor
+// transparency log entry).
+type CosignKeylessVerifier struct {
+	// CertIdentity and CertOIDCIssuer pin the expected signer identity,
+	// e.g. the GitHub Actions workflow that produced the signature.
+	CertIdentity   string
+	CertOIDCIssuer string
+}
+
+func (v CosignKeylessVerifier) Verify(ctx context.Context, data, signature []byte) error {
+	sigFile, err := os.CreateTemp("", "octocov-sig-*")
+	if err != nil {
+		return fmt.Errorf("cosign verify-blob: %w", err)
+	}
+	defer os.Remove(sigFile.Name())
+	if _, err := sigFile.Write(signature); err != nil {
+		_ = sigFile.Close()
+		return fmt.Errorf("cosign verify-blob: %w", err)
+	}
+	if err := sigFile.Close(); err != nil {
+		return fmt.Errorf("cosign verify-blob: %w", err)
+	}
+
+	args := []string{"verify-blob", "--signature", sigFile.Name()}
+	if v.CertIdentity != "" {
+		args = append(args, "--certificate-identity", v.CertIdentity)
+	}
+	if v.CertOIDCIssuer != "" {
+		args = append(args, "--certificate-oidc-issuer", v.CertOIDCIssuer)
+	}
+	args = append(args, "-")
+	cmd := exec.CommandContext(ctx, "cosign", args...)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("cosign verify-blob: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package sign
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeEd25519KeyPair(t *testing.T) (privPath, pubPath string) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privBytes, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubBytes, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	privPath = filepath.Join(dir, "key.pem")
+	pubPath = filepath.Join(dir, "key.pub.pem")
+	if err := os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privBytes}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubBytes}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return privPath, pubPath
+}
+
+func TestEd25519SignVerifyRoundTrip(t *testing.T) {
+	privPath, pubPath := writeEd25519KeyPair(t)
+	signer, err := LoadEd25519Signer(privPath)
+	if err != nil {
+		t.Fatalf("LoadEd25519Signer() error = %v", err)
+	}
+	verifier, err := LoadEd25519Verifier(pubPath)
+	if err != nil {
+		t.Fatalf("LoadEd25519Verifier() error = %v", err)
+	}
+	ctx := context.Background()
+	data := []byte(`{"coverage": 82.3}`)
+	sig, err := signer.Sign(ctx, data)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	if err := verifier.Verify(ctx, data, sig); err != nil {
+		t.Errorf("Verify() error = %v, want a valid signature to verify", err)
+	}
+}
+
+func TestEd25519VerifyRejectsTamperedData(t *testing.T) {
+	privPath, pubPath := writeEd25519KeyPair(t)
+	signer, err := LoadEd25519Signer(privPath)
+	if err != nil {
+		t.Fatalf("LoadEd25519Signer() error = %v", err)
+	}
+	verifier, err := LoadEd25519Verifier(pubPath)
+	if err != nil {
+		t.Fatalf("LoadEd25519Verifier() error = %v", err)
+	}
+	ctx := context.Background()
+	sig, err := signer.Sign(ctx, []byte(`{"coverage": 82.3}`))
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	if err := verifier.Verify(ctx, []byte(`{"coverage": 99.9}`), sig); err == nil {
+		t.Error("Verify() succeeded on tampered data, want an error")
+	}
+}
+
+func TestLoadEd25519SignerRejectsNonPEMFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "not-a-key.pem")
+	// A file with no PEM block at all must be rejected before any key
+	// parsing is attempted.
+	if err := os.WriteFile(path, []byte("not a pem file"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadEd25519Signer(path); err == nil {
+		t.Error("LoadEd25519Signer() succeeded on a non-PEM file, want an error")
+	}
+}